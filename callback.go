@@ -0,0 +1,113 @@
+package v8engine
+
+// #include "v8engine.h"
+import "C"
+
+import (
+	"fmt"
+	"sync"
+	"unsafe"
+)
+
+// FunctionCallback is a Go function exposed to JavaScript through a
+// FunctionTemplate. A panic inside the callback is recovered and thrown
+// into the VM as a JS exception rather than crashing the host process.
+type FunctionCallback func(info *FunctionCallbackInfo) *Value
+
+// FunctionCallbackInfo carries the arguments, receiver and context of a
+// single JS-to-Go function call. It is only valid for the duration of
+// the callback.
+type FunctionCallbackInfo struct {
+	args []*Value
+	this *Object
+	ctx  *Context
+}
+
+// Args returns the arguments the function was called with.
+func (i *FunctionCallbackInfo) Args() []*Value {
+	return i.args
+}
+
+// This returns the function's receiver (the `this` value).
+func (i *FunctionCallbackInfo) This() *Object {
+	return i.this
+}
+
+// Context returns the context the function is executing in.
+func (i *FunctionCallbackInfo) Context() *Context {
+	return i.ctx
+}
+
+var (
+	callbackTableLock sync.Mutex
+	nextCallbackToken int
+	callbackFuncs     = make(map[int]FunctionCallback)
+)
+
+func callbackValue(cv C.CallbackValue, ctx *Context) *Value {
+	if cv.value == nil {
+		return nil
+	}
+	return newValue(cv.value, cv.kinds, ctx)
+}
+
+func setRtnError(cErr *C.RtnError, msg string) {
+	cErr.msg = C.CString(msg)
+}
+
+// goFunctionCallback is invoked from v8engine.cc every time a JS function
+// created from a FunctionTemplate is called. It looks the Go callback up
+// by the same token scheme ResolveModule uses, recovers panics into a
+// JSError, and hands the result back to C++ to either return or throw.
+//
+//export goFunctionCallback
+func goFunctionCallback(token C.int, cInfo *C.FunctionCallbackInfo) (rtn C.RtnValue) {
+	callbackTableLock.Lock()
+	callback := callbackFuncs[int(token)]
+	callbackTableLock.Unlock()
+
+	defer func() {
+		if r := recover(); r != nil {
+			rtn = C.RtnValue{}
+			setRtnError(&rtn.error, fmt.Sprintf("%v", r))
+		}
+	}()
+
+	if callback == nil {
+		setRtnError(&rtn.error, "v8engine: no callback registered for token")
+		return rtn
+	}
+
+	// cInfo.context is the same Persistent<Context> the Go Context for
+	// this call already owns and will dispose of itself, so this wrapper
+	// only borrows it and must not be given its own finalizer. iso is
+	// likewise borrowed, not owned: its finalizer only runs via the
+	// Isolate the caller created and holds onto elsewhere.
+	ctx := &Context{ptr: cInfo.context, iso: &Isolate{ptr: cInfo.isolate}}
+
+	cArgs := unsafe.Slice(cInfo.args, int(cInfo.args_count))
+	args := make([]*Value, len(cArgs))
+	for i, cv := range cArgs {
+		args[i] = callbackValue(cv, ctx)
+	}
+
+	var this *Object
+	if thisValue := callbackValue(cInfo.this_value, ctx); thisValue != nil {
+		this = thisValue.Object()
+	}
+
+	info := &FunctionCallbackInfo{
+		args: args,
+		this: this,
+		ctx:  ctx,
+	}
+
+	result := callback(info)
+	if result == nil {
+		return rtn
+	}
+
+	rtn.value = result.ptr
+	rtn.kinds = result.kinds
+	return rtn
+}
@@ -0,0 +1,114 @@
+package v8engine
+
+// #include <stdlib.h>
+// #include "v8engine.h"
+import "C"
+
+import (
+	"runtime"
+	"unsafe"
+)
+
+// Context is a sandboxed execution environment within an Isolate. Multiple
+// Contexts may share a single Isolate, allowing, for example, the same
+// compiled helper functions to be reused cheaply across many otherwise
+// independent scripts.
+type Context struct {
+	iso *Isolate
+	ptr C.ContextPtr
+}
+
+// NewContext creates a new Context within the given Isolate.
+func NewContext(iso *Isolate) *Context {
+	ctx := &Context{
+		iso: iso,
+		ptr: C.NewContext(iso.ptr),
+	}
+
+	runtime.SetFinalizer(ctx, (*Context).finalizer)
+
+	return ctx
+}
+
+// Isolate returns the Isolate this Context belongs to.
+func (c *Context) Isolate() *Isolate {
+	return c.iso
+}
+
+// Run executes a script in the context, returning the result.
+func (c *Context) Run(source string, origin string) (*Value, error) {
+	cSource := C.CString(source)
+	cOrigin := C.CString(origin)
+	defer C.free(unsafe.Pointer(cSource))
+	defer C.free(unsafe.Pointer(cOrigin))
+
+	rtn := C.Run(c.ptr, cSource, cOrigin)
+	return getValue(rtn, c), getError(rtn)
+}
+
+// LoadModule compiles source as an ES module, instantiates it (resolving
+// any imports via resolve), and evaluates it in the context.
+func (c *Context) LoadModule(source string, origin string, resolve ModuleResolverCallback) error {
+	cSource := C.CString(source)
+	cOrigin := C.CString(origin)
+	defer C.free(unsafe.Pointer(cSource))
+	defer C.free(unsafe.Pointer(cOrigin))
+
+	resolverTableLock.Lock()
+	nextResolverToken++
+	token := nextResolverToken
+	resolverFuncs[token] = resolve
+	resolverTableLock.Unlock()
+
+	cToken := C.int(token)
+
+	rtnErr := C.LoadModule(c.ptr, cSource, cOrigin, cToken)
+
+	resolverTableLock.Lock()
+	delete(resolverFuncs, token)
+	resolverTableLock.Unlock()
+
+	return getRtnError(rtnErr)
+}
+
+// Global returns the context's global object, so that callers can expose
+// Go functions to it, e.g. ctx.Global().Set("print", printFn).
+func (c *Context) Global() *Object {
+	rtn := C.ContextGlobal(c.ptr)
+	return newValue(rtn.value, rtn.kinds, c).Object()
+}
+
+// NewString creates a JavaScript string Value in this context's isolate.
+func (c *Context) NewString(s string) *Value {
+	cStr := C.CString(s)
+	defer C.free(unsafe.Pointer(cStr))
+
+	rtn := C.NewValueString(c.iso.ptr, cStr)
+	return getValue(rtn, c)
+}
+
+// PerformMicrotaskCheckpoint runs any microtasks (e.g. Promise
+// continuations) that are currently queued on the isolate.
+func (c *Context) PerformMicrotaskCheckpoint() {
+	C.ContextPerformMicrotaskCheckpoint(c.ptr)
+}
+
+// Send delivers msg to v8engine.dispatch in the context, as installed by
+// NewDispatcher. It errors if no Dispatcher has been created for ctx.
+func (c *Context) Send(msg []byte) error {
+	var msgPointer unsafe.Pointer
+	if len(msg) > 0 {
+		msgPointer = C.CBytes(msg)
+		defer C.free(msgPointer)
+	}
+
+	rtnErr := C.Send(c.ptr, C.size_t(len(msg)), msgPointer)
+	return getRtnError(rtnErr)
+}
+
+func (c *Context) finalizer() {
+	C.DisposeContext(c.ptr)
+	c.ptr = nil
+
+	runtime.SetFinalizer(c, nil)
+}
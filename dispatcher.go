@@ -0,0 +1,199 @@
+package v8engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// Handler responds to a method call initiated from JavaScript via
+// v8engine.recv(...). A returned error's message is sent back to the JS
+// caller in the error field of the JSON response.
+type Handler func(params json.RawMessage) (interface{}, error)
+
+// rpcMessage is the wire format sent in both directions across Send /
+// v8engine.recv: {id, method, params} for requests and notifications,
+// {id, result} or {id, error} for responses.
+type rpcMessage struct {
+	ID     int64           `json:"id"`
+	Method string          `json:"method,omitempty"`
+	Params json.RawMessage `json:"params,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// Dispatcher turns Context.Send's raw byte channel into a structured
+// JSON-RPC-style bridge: Go-initiated calls are framed with a
+// correlation ID and resolved when the matching response arrives, while
+// JS-initiated calls are routed to Handlers registered with HandleFunc
+// and invoked through a single exposed v8engine.recv global.
+type Dispatcher struct {
+	ctx *Context
+
+	nextID int64
+
+	handlersLock sync.RWMutex
+	handlers     map[string]Handler
+
+	pendingLock sync.Mutex
+	pending     map[int64]chan rpcMessage
+}
+
+// NewDispatcher creates a Dispatcher over ctx and installs the
+// v8engine.recv global that JS uses to deliver messages back to Go.
+func NewDispatcher(ctx *Context) (*Dispatcher, error) {
+	d := &Dispatcher{
+		ctx:      ctx,
+		handlers: make(map[string]Handler),
+		pending:  make(map[int64]chan rpcMessage),
+	}
+
+	tmpl := NewObjectTemplate(ctx.Isolate())
+	tmpl.Set("recv", NewFunctionTemplate(ctx.Isolate(), d.recv))
+
+	inst, err := tmpl.NewInstance(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ctx.Global().Set("v8engine", inst); err != nil {
+		return nil, err
+	}
+
+	// Installs the JS-side half of Context.Send: v8engine.dispatch is
+	// what the native Send shim calls for every message, routing it to
+	// v8engine.onMessage if the script has registered one, or queuing it
+	// on v8engine.__queue for a script that hasn't set one up yet.
+	if _, err := ctx.Run(dispatchBootstrapJS, "<v8engine-dispatch-bootstrap>"); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+const dispatchBootstrapJS = `(function(v8engine) {
+	v8engine.__queue = [];
+	v8engine.dispatch = function(msg) {
+		if (typeof v8engine.onMessage === "function") {
+			v8engine.onMessage(msg);
+		} else {
+			v8engine.__queue.push(msg);
+		}
+	};
+})(v8engine);`
+
+// HandleFunc registers handler to serve calls and notifications sent
+// from JS for the given method name.
+func (d *Dispatcher) HandleFunc(method string, handler Handler) {
+	d.handlersLock.Lock()
+	defer d.handlersLock.Unlock()
+	d.handlers[method] = handler
+}
+
+// Call sends a method call to JS and blocks until the matching response
+// arrives, ctx is cancelled (terminating the isolate and returning
+// ErrTerminated), or the isolate reports an error.
+func (d *Dispatcher) Call(ctx context.Context, method string, params interface{}) (json.RawMessage, error) {
+	id := atomic.AddInt64(&d.nextID, 1)
+
+	rawParams, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	respCh := make(chan rpcMessage, 1)
+	d.pendingLock.Lock()
+	d.pending[id] = respCh
+	d.pendingLock.Unlock()
+	defer func() {
+		d.pendingLock.Lock()
+		delete(d.pending, id)
+		d.pendingLock.Unlock()
+	}()
+
+	if err := d.send(rpcMessage{ID: id, Method: method, Params: rawParams}); err != nil {
+		return nil, err
+	}
+
+	select {
+	case resp := <-respCh:
+		if resp.Error != "" {
+			return nil, &JSError{Message: resp.Error}
+		}
+		return resp.Result, nil
+	case <-ctx.Done():
+		d.ctx.Isolate().TerminateExecution()
+		return nil, ErrTerminated
+	}
+}
+
+// Notify sends a one-way event to JS (id == 0); no response is expected.
+func (d *Dispatcher) Notify(method string, params interface{}) error {
+	rawParams, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	return d.send(rpcMessage{Method: method, Params: rawParams})
+}
+
+func (d *Dispatcher) send(msg rpcMessage) error {
+	buf, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return d.ctx.Send(buf)
+}
+
+// recv is the FunctionCallback behind v8engine.recv(jsonString). It
+// either resolves a pending Call (msg.Method == "") or dispatches to a
+// registered Handler (msg.Method != "") and returns the JSON-encoded
+// response as the call's return value.
+func (d *Dispatcher) recv(info *FunctionCallbackInfo) *Value {
+	args := info.Args()
+	if len(args) == 0 {
+		return nil
+	}
+
+	var msg rpcMessage
+	if err := json.Unmarshal([]byte(args[0].String()), &msg); err != nil {
+		return nil
+	}
+
+	if msg.Method == "" {
+		d.pendingLock.Lock()
+		ch, ok := d.pending[msg.ID]
+		d.pendingLock.Unlock()
+		if ok {
+			ch <- msg
+		}
+		return nil
+	}
+
+	d.handlersLock.RLock()
+	handler, ok := d.handlers[msg.Method]
+	d.handlersLock.RUnlock()
+
+	resp := rpcMessage{ID: msg.ID}
+	if !ok {
+		resp.Error = fmt.Sprintf("v8engine: no handler registered for method %q", msg.Method)
+	} else if result, err := handler(msg.Params); err != nil {
+		resp.Error = err.Error()
+	} else if raw, err := json.Marshal(result); err != nil {
+		resp.Error = err.Error()
+	} else {
+		resp.Result = raw
+	}
+
+	if msg.ID == 0 {
+		return nil
+	}
+
+	buf, err := json.Marshal(resp)
+	if err != nil {
+		return nil
+	}
+
+	return info.Context().NewString(string(buf))
+}
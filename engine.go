@@ -9,139 +9,160 @@ package v8engine
 import "C"
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
-	"runtime"
 	"sync"
+	"time"
 	"unsafe"
 )
 
-var v8init sync.Once
+// ErrTerminated is returned by RunWithContext when the script was killed
+// because the Go context passed to it was cancelled or its deadline
+// expired, rather than because the script itself failed.
+var ErrTerminated = errors.New("v8engine: execution terminated")
 
-// Engine is a standalone instance of the V8 engine (isolate + context)
+// Engine bundles an Isolate and a Context, for callers that only need a
+// single context per isolate. Callers that want to share one Isolate
+// across several Contexts should create those directly with NewIsolate
+// and NewContext instead.
 type Engine struct {
-	contextPtr C.ContextPtr
+	Isolate *Isolate
+	Context *Context
 }
 
-// NewEngine creates a new V8 engine (isolate + context)
+// NewEngine creates a new V8 engine (isolate + context).
 func NewEngine() *Engine {
-	v8init.Do(func() {
-		C.InitV8()
-	})
+	iso := NewIsolate()
+	ctx := NewContext(iso)
 
-	contextPtr := C.NewContext()
-
-	engine := &Engine{
-		contextPtr: contextPtr,
+	return &Engine{
+		Isolate: iso,
+		Context: ctx,
 	}
-
-	runtime.SetFinalizer(engine, (*Engine).finalizer)
-
-	return engine
 }
 
-// Run executes a script in the engine, returning the result
+// Run executes a script in the engine, returning the result.
 func (e *Engine) Run(source string, origin string) (*Value, error) {
-	cSource := C.CString(source)
-	cOrigin := C.CString(origin)
-	defer C.free(unsafe.Pointer(cSource))
-	defer C.free(unsafe.Pointer(cOrigin))
-
-	rtn := C.Run(e.contextPtr, cSource, cOrigin)
-	return getValue(rtn), getError(rtn)
+	return e.Context.Run(source, origin)
 }
 
-// LoadModule executes a script in the engine, returning the result
-func (e *Engine) LoadModule(source string, origin string, resolve ModuleResolverCallback) int {
-	cSource := C.CString(source)
-	cOrigin := C.CString(origin)
-	defer C.free(unsafe.Pointer(cSource))
-	defer C.free(unsafe.Pointer(cOrigin))
-
-	resolverTableLock.Lock()
-	nextResolverToken++
-	token := nextResolverToken
-	resolverFuncs[token] = resolve
-	resolverTableLock.Unlock()
+// RunWithContext executes a script as in Run, but starts a watchdog
+// goroutine that calls Isolate.TerminateExecution if ctx is cancelled or
+// its deadline fires before the script finishes on its own, returning
+// ErrTerminated in that case.
+func (e *Engine) RunWithContext(ctx context.Context, source string, origin string) (*Value, error) {
+	type result struct {
+		val *Value
+		err error
+	}
 
-	cToken := C.int(token)
+	done := make(chan result, 1)
+	go func() {
+		val, err := e.Context.Run(source, origin)
+		done <- result{val, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.val, r.err
+	case <-ctx.Done():
+		e.Isolate.TerminateExecution()
+		<-done
+		return nil, ErrTerminated
+	}
+}
 
-	rtn := C.LoadModule(e.contextPtr, cSource, cOrigin, cToken)
+// RunAsync runs source as in RunWithContext, then drains the isolate's
+// microtask queue and, if the result is a pending Promise, keeps pumping
+// microtasks (respecting ctx) until it settles. It returns the resolved
+// value, or a *JSError carrying the rejection reason if the promise
+// rejects.
+func (e *Engine) RunAsync(ctx context.Context, source string, origin string) (*Value, error) {
+	val, err := e.RunWithContext(ctx, source, origin)
+	if err != nil {
+		return nil, err
+	}
 
-	resolverTableLock.Lock()
-	delete(resolverFuncs, token)
-	resolverTableLock.Unlock()
+	for {
+		e.Context.PerformMicrotaskCheckpoint()
 
-	return int(rtn)
-}
+		promise, ok := val.AsPromise()
+		if !ok {
+			return val, nil
+		}
 
-// Send sends bytes to V8
-func (e *Engine) Send(msg []byte) error {
-	msgPointer := C.CBytes(msg)
+		switch promise.State() {
+		case Fulfilled:
+			return promise.Result()
+		case Rejected:
+			reason, err := promise.Result()
+			if err != nil {
+				return nil, err
+			}
+			return nil, &JSError{Message: reason.String()}
+		}
 
-	code := C.Send(e.contextPtr, C.size_t(len(msg)), msgPointer)
-	if code != 0 {
-		return fmt.Errorf("expected 0, got %d", code)
+		select {
+		case <-ctx.Done():
+			e.Isolate.TerminateExecution()
+			return nil, ErrTerminated
+		case <-time.After(microtaskPollBackoff):
+		}
 	}
-	return nil
 }
 
-func (e *Engine) finalizer() {
-	C.DisposeContext(e.contextPtr)
-	e.contextPtr = nil
+// microtaskPollBackoff bounds how often RunAsync re-checks a pending
+// Promise's state between microtask checkpoints. A Promise that's waiting
+// on external work (e.g. a Dispatcher round-trip to Go) won't settle on
+// the next checkpoint, so polling in a tight loop would just pin a CPU
+// core; this keeps the wait cheap without needing a settle notification
+// threaded back from Send/recv.
+const microtaskPollBackoff = time.Millisecond
+
+// LoadModule compiles source as an ES module, instantiates it (resolving
+// any imports via resolve), and evaluates it in the engine.
+func (e *Engine) LoadModule(source string, origin string, resolve ModuleResolverCallback) error {
+	return e.Context.LoadModule(source, origin, resolve)
+}
 
-	runtime.SetFinalizer(e, nil)
+// Send sends bytes to V8.
+func (e *Engine) Send(msg []byte) error {
+	return e.Context.Send(msg)
 }
 
-func getValue(rtn C.RtnValue) *Value {
+func getValue(rtn C.RtnValue, ctx *Context) *Value {
 	if rtn.value == nil {
 		return nil
 	}
-	v := &Value{rtn.value}
-	runtime.SetFinalizer(v, (*Value).finalizer)
-	return v
+	return newValue(rtn.value, rtn.kinds, ctx)
 }
 
 func getError(rtn C.RtnValue) error {
-	if rtn.error.msg == nil {
+	return getRtnError(rtn.error)
+}
+
+func getRtnError(cErr C.RtnError) error {
+	if cErr.msg == nil {
 		return nil
 	}
 	err := &JSError{
-		Message:    C.GoString(rtn.error.msg),
-		Location:   C.GoString(rtn.error.location),
-		StackTrace: C.GoString(rtn.error.stack),
+		Message:    C.GoString(cErr.msg),
+		Location:   C.GoString(cErr.location),
+		StackTrace: C.GoString(cErr.stack),
 	}
-	C.free(unsafe.Pointer(rtn.error.msg))
-	C.free(unsafe.Pointer(rtn.error.location))
-	C.free(unsafe.Pointer(rtn.error.stack))
+	C.free(unsafe.Pointer(cErr.msg))
+	C.free(unsafe.Pointer(cErr.location))
+	C.free(unsafe.Pointer(cErr.stack))
 	return err
 }
 
-// Value represents a JavaScript value
-type Value struct {
-	ptr C.ValuePtr
-}
-
-// String returns the string representation of the value
-func (v *Value) String() string {
-	s := C.ValueToString(v.ptr)
-	defer C.free(unsafe.Pointer(s))
-
-	return C.GoString(s)
-}
-
-func (v *Value) finalizer() {
-	C.DisposeValue(v.ptr)
-	v.ptr = nil
-	runtime.SetFinalizer(v, nil)
-}
-
 // Version returns the version of the V8 engine
 func Version() string {
 	return C.GoString(C.Version())
 }
 
-
 var (
 	resolverTableLock sync.Mutex
 	nextResolverToken int
@@ -169,7 +190,6 @@ func ResolveModule(moduleSpecifier *C.char, referrerSpecifier *C.char, resolverT
 	return C.CString(canon), C.int(ret)
 }
 
-
 // JSError is an error that is returned if there is are any
 // JavaScript exceptions handled in the context. When used with the fmt
 // verb `%+v`, will output the JavaScript stack trace, if available.
@@ -0,0 +1,41 @@
+package v8engine
+
+// #include "v8engine.h"
+import "C"
+
+// Function is a JavaScript value known to be callable, such as one
+// created from a FunctionTemplate or returned by running script that
+// evaluates to a function.
+type Function struct {
+	*Value
+}
+
+// Function returns the value as a Function. It returns nil if the value
+// is not a JavaScript function.
+func (v *Value) Function() *Function {
+	if !v.IsFunction() {
+		return nil
+	}
+	return &Function{v}
+}
+
+// Call invokes the function with the given receiver and arguments.
+func (f *Function) Call(this *Value, args ...*Value) (*Value, error) {
+	var thisPtr C.ValuePtr
+	if this != nil {
+		thisPtr = this.ptr
+	}
+
+	argv := make([]C.ValuePtr, len(args))
+	for i, a := range args {
+		argv[i] = a.ptr
+	}
+
+	var argvPtr *C.ValuePtr
+	if len(argv) > 0 {
+		argvPtr = &argv[0]
+	}
+
+	rtn := C.ValueCall(f.ptr, f.ctx.ptr, thisPtr, argvPtr, C.int(len(argv)))
+	return getValue(rtn, f.ctx), getError(rtn)
+}
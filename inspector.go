@@ -0,0 +1,100 @@
+package v8engine
+
+// #include <stdlib.h>
+// #include "v8engine.h"
+import "C"
+
+import (
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+// InspectorMessageFunc receives outbound Chrome DevTools Protocol
+// messages for a session, for forwarding to whatever transport the
+// embedder is using (typically a WebSocket, see the inspector/http
+// subpackage).
+type InspectorMessageFunc func(sessionID int, message []byte)
+
+// Inspector attaches the V8 Inspector (the engine behind Chrome
+// DevTools/chrome://inspect) to an Isolate, so that scripts run through
+// Engine.Run/LoadModule can be debugged, profiled, and stepped through
+// from a DevTools frontend.
+type Inspector struct {
+	ptr C.InspectorPtr
+}
+
+var (
+	inspectorTableLock sync.Mutex
+	nextInspectorToken int
+	inspectorFuncs     = make(map[int]InspectorMessageFunc)
+)
+
+// NewInspector creates an Inspector on iso. onMessage is invoked for
+// every outbound protocol message, tagged with the session it belongs
+// to.
+func NewInspector(iso *Isolate, onMessage InspectorMessageFunc) *Inspector {
+	inspectorTableLock.Lock()
+	nextInspectorToken++
+	token := nextInspectorToken
+	inspectorFuncs[token] = onMessage
+	inspectorTableLock.Unlock()
+
+	insp := &Inspector{
+		ptr: C.NewInspector(iso.ptr, C.int(token)),
+	}
+
+	runtime.SetFinalizer(insp, (*Inspector).finalizer)
+
+	return insp
+}
+
+// ContextCreated tells the inspector about a Context so that DevTools
+// can see it (e.g. in the console context selector), labelled name.
+func (i *Inspector) ContextCreated(ctx *Context, name string) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	C.InspectorContextCreated(i.ptr, ctx.ptr, cName)
+}
+
+// Connect starts a new DevTools session and returns its ID, to be passed
+// to Send and Disconnect.
+func (i *Inspector) Connect() int {
+	return int(C.InspectorConnect(i.ptr))
+}
+
+// Disconnect ends a session previously started with Connect.
+func (i *Inspector) Disconnect(sessionID int) {
+	C.InspectorDisconnect(i.ptr, C.int(sessionID))
+}
+
+// Send delivers an inbound Chrome DevTools Protocol message (as sent by
+// the frontend) for the given session.
+func (i *Inspector) Send(sessionID int, message []byte) {
+	if len(message) == 0 {
+		return
+	}
+	C.InspectorSend(i.ptr, C.int(sessionID), (*C.char)(unsafe.Pointer(&message[0])), C.size_t(len(message)))
+}
+
+func (i *Inspector) finalizer() {
+	C.DisposeInspector(i.ptr)
+	i.ptr = nil
+	runtime.SetFinalizer(i, nil)
+}
+
+// goInspectorSend is called from the GoInspector::Channel in
+// v8engine_inspector.cc for every outbound protocol message.
+//
+//export goInspectorSend
+func goInspectorSend(token C.int, sessionID C.int, message *C.char, length C.size_t) {
+	inspectorTableLock.Lock()
+	onMessage := inspectorFuncs[int(token)]
+	inspectorTableLock.Unlock()
+
+	if onMessage == nil {
+		return
+	}
+	onMessage(int(sessionID), C.GoBytes(unsafe.Pointer(message), C.int(length)))
+}
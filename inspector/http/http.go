@@ -0,0 +1,70 @@
+// Package http wires a v8engine.Inspector up to an HTTP server speaking
+// the WebSocket transport that the Chrome DevTools frontend (and
+// chrome://inspect, and editors like VS Code) expects.
+package http
+
+import (
+	"net/http"
+	"sync"
+
+	"golang.org/x/net/websocket"
+
+	v8engine "github.com/BlazerodJS/v8engine"
+)
+
+// Handler serves the Chrome DevTools Protocol over WebSocket. Each
+// connection gets its own Inspector session; register Contexts with
+// Inspector before they're likely to be inspected.
+type Handler struct {
+	Inspector *v8engine.Inspector
+
+	mu    sync.Mutex
+	conns map[int]*websocket.Conn
+}
+
+// NewHandler creates a Handler backed by a new Inspector on iso.
+func NewHandler(iso *v8engine.Isolate) *Handler {
+	h := &Handler{conns: make(map[int]*websocket.Conn)}
+	h.Inspector = v8engine.NewInspector(iso, h.sendToSession)
+	return h
+}
+
+func (h *Handler) sendToSession(sessionID int, message []byte) {
+	h.mu.Lock()
+	conn := h.conns[sessionID]
+	h.mu.Unlock()
+
+	if conn == nil {
+		return
+	}
+	websocket.Message.Send(conn, string(message))
+}
+
+// ServeHTTP upgrades the request to a WebSocket and bridges it to a new
+// Inspector session for the lifetime of the connection.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	websocket.Handler(h.serveSession).ServeHTTP(w, r)
+}
+
+func (h *Handler) serveSession(ws *websocket.Conn) {
+	sessionID := h.Inspector.Connect()
+
+	h.mu.Lock()
+	h.conns[sessionID] = ws
+	h.mu.Unlock()
+
+	defer func() {
+		h.mu.Lock()
+		delete(h.conns, sessionID)
+		h.mu.Unlock()
+		h.Inspector.Disconnect(sessionID)
+	}()
+
+	for {
+		var msg string
+		if err := websocket.Message.Receive(ws, &msg); err != nil {
+			return
+		}
+		h.Inspector.Send(sessionID, []byte(msg))
+	}
+}
@@ -0,0 +1,111 @@
+package v8engine
+
+// #include "v8engine.h"
+import "C"
+
+import (
+	"runtime"
+	"sync"
+)
+
+var v8init sync.Once
+
+// ResourceConstraints bounds the amount of heap an Isolate is allowed to
+// grow to. A zero value leaves V8's own defaults in place.
+type ResourceConstraints struct {
+	MaxOldGenerationSizeInBytes   uint64
+	MaxYoungGenerationSizeInBytes uint64
+}
+
+// Isolate is a single isolated instance of the V8 engine. An Isolate owns
+// its own heap and can never share objects with another Isolate, but a
+// single Isolate may be shared by multiple Contexts so that, for example,
+// compiled helper functions are only parsed once.
+type Isolate struct {
+	ptr C.IsolatePtr
+}
+
+// NewIsolate creates a new V8 isolate with the default resource
+// constraints.
+func NewIsolate() *Isolate {
+	return NewIsolateWithConstraints(ResourceConstraints{})
+}
+
+// NewIsolateWithConstraints creates a new V8 isolate bounded by the given
+// ResourceConstraints.
+func NewIsolateWithConstraints(constraints ResourceConstraints) *Isolate {
+	v8init.Do(func() {
+		C.InitV8()
+	})
+
+	cConstraints := C.ResourceConstraints{
+		max_old_generation_size_in_bytes:   C.size_t(constraints.MaxOldGenerationSizeInBytes),
+		max_young_generation_size_in_bytes: C.size_t(constraints.MaxYoungGenerationSizeInBytes),
+	}
+
+	iso := &Isolate{
+		ptr: C.NewIsolate(cConstraints),
+	}
+
+	runtime.SetFinalizer(iso, (*Isolate).finalizer)
+
+	return iso
+}
+
+// HeapStatistics holds a snapshot of an Isolate's V8 heap usage.
+type HeapStatistics struct {
+	TotalHeapSize            uint64
+	TotalHeapSizeExecutable  uint64
+	TotalPhysicalSize        uint64
+	TotalAvailableSize       uint64
+	UsedHeapSize             uint64
+	HeapSizeLimit            uint64
+	MallocedMemory           uint64
+	ExternalMemory           uint64
+	PeakMallocedMemory       uint64
+	NumberOfNativeContexts   uint64
+	NumberOfDetachedContexts uint64
+}
+
+// GetHeapStatistics returns a snapshot of the isolate's current heap usage.
+func (i *Isolate) GetHeapStatistics() HeapStatistics {
+	hs := C.GetHeapStatistics(i.ptr)
+
+	return HeapStatistics{
+		TotalHeapSize:            uint64(hs.total_heap_size),
+		TotalHeapSizeExecutable:  uint64(hs.total_heap_size_executable),
+		TotalPhysicalSize:        uint64(hs.total_physical_size),
+		TotalAvailableSize:       uint64(hs.total_available_size),
+		UsedHeapSize:             uint64(hs.used_heap_size),
+		HeapSizeLimit:            uint64(hs.heap_size_limit),
+		MallocedMemory:           uint64(hs.malloced_memory),
+		ExternalMemory:           uint64(hs.external_memory),
+		PeakMallocedMemory:       uint64(hs.peak_malloced_memory),
+		NumberOfNativeContexts:   uint64(hs.number_of_native_contexts),
+		NumberOfDetachedContexts: uint64(hs.number_of_detached_contexts),
+	}
+}
+
+// TerminateExecution schedules a termination exception to interrupt any
+// JavaScript currently running in the isolate, in any Context created
+// from it. It is safe to call from any goroutine, including while the
+// isolate is executing a script on another goroutine.
+func (i *Isolate) TerminateExecution() {
+	C.TerminateExecution(i.ptr)
+}
+
+// Dispose releases the isolate's resources. The isolate, and any Context
+// created from it, must not be used afterwards.
+func (i *Isolate) Dispose() {
+	if i.ptr == nil {
+		return
+	}
+	C.DisposeIsolate(i.ptr)
+	i.ptr = nil
+
+	runtime.SetFinalizer(i, nil)
+}
+
+func (i *Isolate) finalizer() {
+	i.Dispose()
+}
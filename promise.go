@@ -0,0 +1,58 @@
+package v8engine
+
+// #include "v8engine.h"
+import "C"
+
+// PromiseState describes where a Promise is in its lifecycle.
+type PromiseState int
+
+const (
+	Pending PromiseState = iota
+	Fulfilled
+	Rejected
+)
+
+// Promise is a JavaScript value known to be a Promise.
+type Promise struct {
+	*Value
+}
+
+// AsPromise returns the value as a Promise. The second return value is
+// false if the value is not a JavaScript Promise.
+func (v *Value) AsPromise() (*Promise, bool) {
+	if !v.IsPromise() {
+		return nil, false
+	}
+	return &Promise{v}, true
+}
+
+// State returns the promise's current state.
+func (p *Promise) State() PromiseState {
+	return PromiseState(C.ValuePromiseState(p.ptr))
+}
+
+// Result returns the promise's fulfillment value or rejection reason. It
+// returns an error if called while the promise is still Pending, without
+// calling into V8, whose Promise::Result aborts the whole process on a
+// pending promise.
+func (p *Promise) Result() (*Value, error) {
+	rtn := C.ValuePromiseResult(p.ptr, p.ctx.ptr)
+	return getValue(rtn, p.ctx), getError(rtn)
+}
+
+// Then registers fulfillment and rejection handlers, returning the
+// resulting chained promise. onRejected may be nil.
+func (p *Promise) Then(onFulfilled, onRejected *Function) *Promise {
+	var onRejectedPtr C.ValuePtr
+	if onRejected != nil {
+		onRejectedPtr = onRejected.ptr
+	}
+
+	rtn := C.ValuePromiseThen(p.ptr, p.ctx.ptr, onFulfilled.ptr, onRejectedPtr)
+	v := getValue(rtn, p.ctx)
+	if v == nil {
+		return nil
+	}
+	promise, _ := v.AsPromise()
+	return promise
+}
@@ -0,0 +1,71 @@
+package v8engine
+
+// #include <stdlib.h>
+// #include "v8engine.h"
+import "C"
+
+import (
+	"runtime"
+	"unsafe"
+)
+
+// CreateSnapshot runs source in a fresh isolate and serializes the
+// resulting heap (including any globals it defines) into a startup
+// snapshot blob. Pass the blob to NewIsolateWithSnapshot to spin up
+// isolates that already have source's globals defined, without
+// re-parsing or re-executing it.
+func CreateSnapshot(source string, origin string) ([]byte, error) {
+	v8init.Do(func() {
+		C.InitV8()
+	})
+
+	cSource := C.CString(source)
+	cOrigin := C.CString(origin)
+	defer C.free(unsafe.Pointer(cSource))
+	defer C.free(unsafe.Pointer(cOrigin))
+
+	rtn := C.CreateSnapshot(cSource, cOrigin)
+	if err := getRtnError(rtn.error); err != nil {
+		return nil, err
+	}
+	defer C.free(unsafe.Pointer(rtn.blob.data))
+
+	return C.GoBytes(unsafe.Pointer(rtn.blob.data), C.int(rtn.blob.length)), nil
+}
+
+// NewIsolateWithSnapshot creates a new Isolate preloaded from a snapshot
+// blob previously produced by CreateSnapshot.
+func NewIsolateWithSnapshot(blob []byte) *Isolate {
+	v8init.Do(func() {
+		C.InitV8()
+	})
+
+	if len(blob) == 0 {
+		panic("v8engine: empty snapshot blob")
+	}
+
+	cConstraints := C.ResourceConstraints{}
+
+	iso := &Isolate{
+		ptr: C.NewIsolateFromSnapshot(cConstraints, unsafe.Pointer(&blob[0]), C.size_t(len(blob))),
+	}
+
+	runtime.SetFinalizer(iso, (*Isolate).finalizer)
+
+	return iso
+}
+
+// NewContextFromSnapshot creates a Context from the index-th context
+// stored in iso's startup snapshot (the default context, set via
+// SnapshotCreator.SetDefaultContext when the snapshot was created, is
+// index 0), instead of building a fresh, empty one.
+func NewContextFromSnapshot(iso *Isolate, index int) *Context {
+	ctx := &Context{
+		iso: iso,
+		ptr: C.NewContextFromSnapshot(iso.ptr, C.int(index)),
+	}
+
+	runtime.SetFinalizer(ctx, (*Context).finalizer)
+
+	return ctx
+}
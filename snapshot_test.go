@@ -0,0 +1,48 @@
+package v8engine
+
+import "testing"
+
+// TestSnapshotRoundTrip verifies that a context created from a snapshot
+// already has the globals baked into it by CreateSnapshot, without the
+// source that defined them ever running again in the new isolate.
+func TestSnapshotRoundTrip(t *testing.T) {
+	blob, err := CreateSnapshot(`
+		globalThis.sideEffects = (globalThis.sideEffects || 0) + 1;
+		function greet(name) {
+			sideEffects++;
+			return "hello, " + name;
+		}
+	`, "bootstrap.js")
+	if err != nil {
+		t.Fatalf("CreateSnapshot: %v", err)
+	}
+
+	iso := NewIsolateWithSnapshot(blob)
+	defer iso.Dispose()
+
+	ctx := NewContextFromSnapshot(iso, 0)
+
+	val, err := ctx.Run(`typeof greet`, "check.js")
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if got := val.String(); got != "function" {
+		t.Fatalf("greet should already be defined in the snapshotted context, got typeof %q", got)
+	}
+
+	val, err = ctx.Run(`sideEffects`, "check.js")
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if got := val.Int32(); got != 1 {
+		t.Fatalf("sideEffects = %d, want 1 (bootstrap.js must not re-run in the new isolate)", got)
+	}
+
+	val, err = ctx.Run(`greet("world")`, "check.js")
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if got := val.String(); got != "hello, world" {
+		t.Fatalf("greet(\"world\") = %q, want %q", got, "hello, world")
+	}
+}
@@ -0,0 +1,84 @@
+package v8engine
+
+// #include <stdlib.h>
+// #include "v8engine.h"
+import "C"
+
+import (
+	"runtime"
+	"unsafe"
+)
+
+// FunctionTemplate is a template for a JavaScript function backed by a Go
+// FunctionCallback. Call GetFunction to turn it into a callable Value
+// bound to a particular Context.
+type FunctionTemplate struct {
+	ptr C.FunctionTemplatePtr
+}
+
+// NewFunctionTemplate creates a FunctionTemplate that invokes callback
+// whenever the resulting JS function is called.
+func NewFunctionTemplate(iso *Isolate, callback FunctionCallback) *FunctionTemplate {
+	callbackTableLock.Lock()
+	nextCallbackToken++
+	token := nextCallbackToken
+	callbackFuncs[token] = callback
+	callbackTableLock.Unlock()
+
+	tmpl := &FunctionTemplate{
+		ptr: C.NewFunctionTemplate(iso.ptr, C.int(token)),
+	}
+
+	runtime.SetFinalizer(tmpl, (*FunctionTemplate).finalizer)
+
+	return tmpl
+}
+
+// GetFunction returns the template's JS function, bound to ctx.
+func (t *FunctionTemplate) GetFunction(ctx *Context) (*Value, error) {
+	rtn := C.FunctionTemplateGetFunction(t.ptr, ctx.ptr)
+	return getValue(rtn, ctx), getError(rtn)
+}
+
+func (t *FunctionTemplate) finalizer() {
+	C.DisposeFunctionTemplate(t.ptr)
+	t.ptr = nil
+	runtime.SetFinalizer(t, nil)
+}
+
+// ObjectTemplate is a template from which JavaScript objects exposing Go
+// functions are constructed.
+type ObjectTemplate struct {
+	ptr C.ObjectTemplatePtr
+}
+
+// NewObjectTemplate creates an empty ObjectTemplate.
+func NewObjectTemplate(iso *Isolate) *ObjectTemplate {
+	tmpl := &ObjectTemplate{
+		ptr: C.NewObjectTemplate(iso.ptr),
+	}
+
+	runtime.SetFinalizer(tmpl, (*ObjectTemplate).finalizer)
+
+	return tmpl
+}
+
+// Set attaches a FunctionTemplate to the object template under name.
+func (t *ObjectTemplate) Set(name string, fn *FunctionTemplate) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	C.ObjectTemplateSet(t.ptr, cName, fn.ptr)
+}
+
+// NewInstance creates a JS object from the template, bound to ctx.
+func (t *ObjectTemplate) NewInstance(ctx *Context) (*Value, error) {
+	rtn := C.ObjectTemplateNewInstance(t.ptr, ctx.ptr)
+	return getValue(rtn, ctx), getError(rtn)
+}
+
+func (t *ObjectTemplate) finalizer() {
+	C.DisposeObjectTemplate(t.ptr)
+	t.ptr = nil
+	runtime.SetFinalizer(t, nil)
+}
@@ -0,0 +1,177 @@
+package v8engine
+
+// #include <stdlib.h>
+// #include "v8engine.h"
+import "C"
+
+import (
+	"encoding/json"
+	"math/big"
+	"runtime"
+	"unsafe"
+)
+
+// Value represents a JavaScript value. Every Value carries a snapshot of
+// its v8::Value type checks (computed once in C++ when the Value is
+// created) so that the IsXxx predicates below are plain Go field reads.
+// It also carries the Context it was produced in, since the C++ side
+// needs to re-enter that Context's scope to safely convert or call it.
+type Value struct {
+	ptr   C.ValuePtr
+	kinds C.ValueKind
+	ctx   *Context
+}
+
+func newValue(ptr C.ValuePtr, kinds C.ValueKind, ctx *Context) *Value {
+	v := &Value{ptr: ptr, kinds: kinds, ctx: ctx}
+	runtime.SetFinalizer(v, (*Value).finalizer)
+	return v
+}
+
+func (v *Value) is(kind C.ValueKind) bool {
+	return v.kinds&kind != 0
+}
+
+// IsString reports whether the value is a JavaScript string.
+func (v *Value) IsString() bool { return v.is(C.KindString) }
+
+// IsNumber reports whether the value is a JavaScript number.
+func (v *Value) IsNumber() bool { return v.is(C.KindNumber) }
+
+// IsBoolean reports whether the value is a JavaScript boolean.
+func (v *Value) IsBoolean() bool { return v.is(C.KindBoolean) }
+
+// IsInt32 reports whether the value fits in a 32-bit signed integer.
+func (v *Value) IsInt32() bool { return v.is(C.KindInt32) }
+
+// IsBigInt reports whether the value is a JavaScript BigInt.
+func (v *Value) IsBigInt() bool { return v.is(C.KindBigInt) }
+
+// IsObject reports whether the value is a JavaScript object.
+func (v *Value) IsObject() bool { return v.is(C.KindObject) }
+
+// IsArray reports whether the value is a JavaScript array.
+func (v *Value) IsArray() bool { return v.is(C.KindArray) }
+
+// IsFunction reports whether the value is a JavaScript function.
+func (v *Value) IsFunction() bool { return v.is(C.KindFunction) }
+
+// IsNull reports whether the value is JavaScript null.
+func (v *Value) IsNull() bool { return v.is(C.KindNull) }
+
+// IsUndefined reports whether the value is JavaScript undefined.
+func (v *Value) IsUndefined() bool { return v.is(C.KindUndefined) }
+
+// IsPromise reports whether the value is a JavaScript Promise.
+func (v *Value) IsPromise() bool { return v.is(C.KindPromise) }
+
+// String returns the string representation of the value.
+func (v *Value) String() string {
+	s := C.ValueToString(v.ptr)
+	defer C.free(unsafe.Pointer(s))
+
+	return C.GoString(s)
+}
+
+// Int32 converts the value to a 32-bit signed integer, following the
+// usual JS ToInt32 coercion rules.
+func (v *Value) Int32() int32 {
+	return int32(C.ValueToInt32(v.ptr, v.ctx.ptr))
+}
+
+// Uint32 converts the value to a 32-bit unsigned integer, following the
+// usual JS ToUint32 coercion rules.
+func (v *Value) Uint32() uint32 {
+	return uint32(C.ValueToUint32(v.ptr, v.ctx.ptr))
+}
+
+// Integer converts the value to an integer, following the usual JS
+// ToInteger coercion rules.
+func (v *Value) Integer() int64 {
+	return int64(C.ValueToInteger(v.ptr, v.ctx.ptr))
+}
+
+// Number converts the value to a float64, following the usual JS
+// ToNumber coercion rules.
+func (v *Value) Number() float64 {
+	return float64(C.ValueToNumber(v.ptr, v.ctx.ptr))
+}
+
+// Boolean converts the value to a bool, following the usual JS
+// ToBoolean coercion rules.
+func (v *Value) Boolean() bool {
+	return C.ValueToBoolean(v.ptr) != 0
+}
+
+// BigInt converts the value to a *big.Int. If the value is not a BigInt,
+// it is coerced first, following the usual JS ToBigInt coercion rules.
+func (v *Value) BigInt() *big.Int {
+	s := C.ValueToBigInt(v.ptr, v.ctx.ptr)
+	defer C.free(unsafe.Pointer(s))
+
+	i := new(big.Int)
+	i.SetString(C.GoString(s), 10)
+	return i
+}
+
+// ArrayBufferContents copies an ArrayBuffer value's backing store into a
+// Go byte slice. It returns nil if the value is not an ArrayBuffer.
+func (v *Value) ArrayBufferContents() []byte {
+	ba := C.ValueToArrayBufferContents(v.ptr)
+	if ba.data == nil {
+		return nil
+	}
+	defer C.free(unsafe.Pointer(ba.data))
+
+	return C.GoBytes(unsafe.Pointer(ba.data), C.int(ba.length))
+}
+
+// MarshalJSON implements the json.Marshaler interface by running the
+// value through JSON.stringify in the isolate.
+func (v *Value) MarshalJSON() ([]byte, error) {
+	s := C.ValueToJSON(v.ptr, v.ctx.ptr)
+	defer C.free(unsafe.Pointer(s))
+
+	return []byte(C.GoString(s)), nil
+}
+
+// Object returns the value as an Object, for property access. It returns
+// nil if the value is not a JavaScript object.
+func (v *Value) Object() *Object {
+	if !v.IsObject() {
+		return nil
+	}
+	return &Object{v}
+}
+
+func (v *Value) finalizer() {
+	C.DisposeValue(v.ptr)
+	v.ptr = nil
+	runtime.SetFinalizer(v, nil)
+}
+
+var _ json.Marshaler = (*Value)(nil)
+
+// Object is a JavaScript value known to be an object, supporting
+// property access in addition to everything a Value supports.
+type Object struct {
+	*Value
+}
+
+// Get returns the value of the named property.
+func (o *Object) Get(key string) (*Value, error) {
+	cKey := C.CString(key)
+	defer C.free(unsafe.Pointer(cKey))
+
+	rtn := C.ValueGet(o.ptr, o.ctx.ptr, cKey)
+	return getValue(rtn, o.ctx), getError(rtn)
+}
+
+// Set sets the named property to the given value.
+func (o *Object) Set(key string, val *Value) error {
+	cKey := C.CString(key)
+	defer C.free(unsafe.Pointer(cKey))
+
+	rtnErr := C.ValueSet(o.ptr, o.ctx.ptr, cKey, val.ptr)
+	return getRtnError(rtnErr)
+}